@@ -0,0 +1,240 @@
+// Command helm-docs renders README documentation for Helm charts found under chart-search-root.
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/norwoodj/helm-docs/pkg/document"
+	"github.com/norwoodj/helm-docs/pkg/helm"
+)
+
+func newHelmDocsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "helm-docs",
+		Short:        "Generate documentation for Helm charts",
+		RunE:         helmDocs,
+		SilenceUsage: true,
+	}
+
+	flags := cmd.PersistentFlags()
+	flags.String("chart-search-root", ".", "directory to search for charts in, resolved through --filesystem-type")
+	flags.String("filesystem-type", string(document.FileFilesystemType), "backend chart-search-root is resolved against: file or git (oci and s3 are reserved, not yet implemented)")
+	flags.Int("parallelism", 1, "number of charts to process concurrently")
+	flags.String("ignore-file", ".helmdocsignore", "name of the file, at chart-search-root, listing chart directories to exclude")
+	flags.String("output-file", "README", "file to write documentation to, relative to each chart's directory; if it has no extension, one is inferred from --output-format")
+	flags.String("output-format", string(document.MarkdownOutputFormat), "format to render documentation as: markdown, json, yaml, or asciidoc")
+	flags.String("sort-values-order", string(document.AlphaNumSortOrder), "order to sort values in the values table: alphanum or file")
+	flags.Bool("document-dependency-values", false, "for charts with dependencies, include the dependency's values in the parent's values table")
+	flags.String("log-level", "info", "level of logs that should be printed")
+
+	if err := viper.BindPFlags(flags); err != nil {
+		panic(err)
+	}
+
+	return cmd
+}
+
+func main() {
+	if err := newHelmDocsCommand().Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// settings is every value helmDocs() needs out of viper, read once up front so the worker pool
+// below never touches viper's global lock from inside a goroutine.
+type settings struct {
+	chartSearchRoot          string
+	filesystemType           document.FilesystemType
+	parallelism              int
+	ignoreFile               string
+	outputFile               string
+	outputFormat             document.OutputFormat
+	sortValuesOrder          document.SortOrder
+	documentDependencyValues bool
+}
+
+func loadSettings() settings {
+	parallelism := viper.GetInt("parallelism")
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	return settings{
+		chartSearchRoot:          viper.GetString("chart-search-root"),
+		filesystemType:           document.FilesystemType(viper.GetString("filesystem-type")),
+		parallelism:              parallelism,
+		ignoreFile:               viper.GetString("ignore-file"),
+		outputFile:               viper.GetString("output-file"),
+		outputFormat:             document.OutputFormat(viper.GetString("output-format")),
+		sortValuesOrder:          document.SortOrder(viper.GetString("sort-values-order")),
+		documentDependencyValues: viper.GetBool("document-dependency-values"),
+	}
+}
+
+// ChartResult is the outcome of rendering documentation for a single chart, including how long it
+// took so benchmarks can report per-chart timings.
+type ChartResult struct {
+	ChartDirectory string
+	Duration       time.Duration
+	Err            error
+}
+
+func helmDocs(cmd *cobra.Command, args []string) error {
+	cfg := loadSettings()
+
+	fsys, err := document.OpenFilesystem(cfg.filesystemType, cfg.chartSearchRoot)
+	if err != nil {
+		return err
+	}
+
+	writer, err := document.OpenWriter(cfg.filesystemType, cfg.chartSearchRoot)
+	if err != nil {
+		return err
+	}
+
+	charts, err := helm.FindCharts(fsys)
+	if err != nil {
+		return err
+	}
+
+	patterns, err := helm.LoadIgnorePatterns(fsys, cfg.ignoreFile)
+	if err != nil {
+		return err
+	}
+	charts = helm.FilterIgnored(charts, patterns)
+
+	results := processCharts(context.Background(), fsys, writer, charts, cfg)
+
+	for _, result := range results {
+		if result.Err != nil {
+			return fmt.Errorf("rendering %s: %w", result.ChartDirectory, result.Err)
+		}
+	}
+
+	return nil
+}
+
+// processCharts fans chart rendering out across a worker pool bounded by cfg.parallelism. Each
+// chart gets its own child context so a slow or cancelled chart doesn't affect its siblings.
+// Results are written into a slice pre-sized to len(charts), so the return value preserves the
+// charts' discovery order regardless of which goroutine finishes first - callers can log results
+// in a stable order even though the rendering itself ran concurrently.
+func processCharts(ctx context.Context, fsys fs.FS, writer document.Writer, charts []helm.ChartInfo, cfg settings) []ChartResult {
+	results := make([]ChartResult, len(charts))
+
+	sem := make(chan struct{}, cfg.parallelism)
+	var wg sync.WaitGroup
+
+	for i, chart := range charts {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int, chart helm.ChartInfo) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			chartCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+
+			start := time.Now()
+			err := renderChart(chartCtx, fsys, writer, chart, cfg)
+			results[i] = ChartResult{
+				ChartDirectory: chart.ChartDirectory,
+				Duration:       time.Since(start),
+				Err:            err,
+			}
+		}(i, chart)
+	}
+
+	wg.Wait()
+	return results
+}
+
+func renderChart(ctx context.Context, fsys fs.FS, writer document.Writer, chart helm.ChartInfo, cfg settings) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var values []document.ValueRow
+	var err error
+	if cfg.documentDependencyValues {
+		values, err = document.LoadDependencyValues(fsys, chart, cfg.sortValuesOrder)
+	} else {
+		values, err = document.LoadValues(fsys, chart.ChartDirectory, cfg.sortValuesOrder)
+	}
+	if err != nil {
+		return err
+	}
+
+	doc := document.NewChartDocument(chart, values)
+
+	rendered, err := document.Render(doc, cfg.outputFormat)
+	if err != nil {
+		return err
+	}
+
+	outputFile := cfg.outputFile
+	if filepath.Ext(outputFile) == "" {
+		ext, err := document.DefaultFileExtension(cfg.outputFormat)
+		if err != nil {
+			return err
+		}
+		outputFile += "." + ext
+	}
+
+	return writer.WriteFile(path.Join(chart.ChartDirectory, outputFile), rendered, 0o644)
+}
+
+// WriteBenchReport writes a machine-readable report of per-chart render timings in the given
+// format ("json" or "csv"), for regression benchmarks to emit alongside go test's own -bench
+// output.
+func WriteBenchReport(w io.Writer, format string, results []ChartResult) error {
+	switch format {
+	case "json":
+		return json.NewEncoder(w).Encode(results)
+	case "csv":
+		return writeBenchReportCSV(w, results)
+	default:
+		return fmt.Errorf("unknown bench report format %q", format)
+	}
+}
+
+func writeBenchReportCSV(w io.Writer, results []ChartResult) error {
+	writer := csv.NewWriter(w)
+	defer writer.Flush()
+
+	if err := writer.Write([]string{"chart", "duration_ns", "error"}); err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		errMsg := ""
+		if result.Err != nil {
+			errMsg = result.Err.Error()
+		}
+
+		if err := writer.Write([]string{
+			result.ChartDirectory,
+			strconv.FormatInt(result.Duration.Nanoseconds(), 10),
+			errMsg,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return writer.Error()
+}
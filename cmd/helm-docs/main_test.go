@@ -1,45 +1,144 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
+	"testing/fstest"
+	"time"
 
 	"github.com/spf13/viper"
 
 	"github.com/norwoodj/helm-docs/pkg/document"
+	"github.com/norwoodj/helm-docs/pkg/helm"
 )
 
 // BenchmarkHelmDocs benchmarks the entire helm-docs command by running on testdata.
 //
 // To run benchmarks, run the command:
 //
-//   go test -run=^$ -bench=. ./cmd/helm-docs
-//
+//	go test -run=^$ -bench=. ./cmd/helm-docs
 func BenchmarkHelmDocs(b *testing.B) {
+	benchmarkHelmDocs(b, "benchmark", runtime.NumCPU(), "markdown")
+}
+
+// BenchmarkHelmDocsSmall processes a single chart, establishing the per-chart floor.
+func BenchmarkHelmDocsSmall(b *testing.B) {
+	benchmarkHelmDocs(b, filepath.Join("benchmark", "small"), runtime.NumCPU(), "markdown")
+}
+
+// BenchmarkHelmDocsMedium processes 8 independent charts to measure worker-pool scaling; see
+// testdata/benchmark/medium for the fixture.
+func BenchmarkHelmDocsMedium(b *testing.B) {
+	benchmarkHelmDocs(b, filepath.Join("benchmark", "medium"), runtime.NumCPU(), "markdown")
+}
+
+// BenchmarkHelmDocsLarge processes 202 charts: 32 top-level charts each with subcharts nested four
+// levels deep under charts/, plus 21 top-level charts with a single subchart each. It is the worst
+// case for chart discovery and values-file resolution; see testdata/benchmark/large for the
+// fixture.
+func BenchmarkHelmDocsLarge(b *testing.B) {
+	benchmarkHelmDocs(b, filepath.Join("benchmark", "large"), runtime.NumCPU(), "markdown")
+}
+
+// BenchmarkHelmDocsSequential pins parallelism to 1, giving a baseline to compare the worker-pool
+// fixtures against.
+func BenchmarkHelmDocsSequential(b *testing.B) {
+	benchmarkHelmDocs(b, "benchmark", 1, "markdown")
+}
+
+// BenchmarkHelmDocsOutputFormats exercises every --output-format serializer against the same
+// fixture, so a regression in the JSON/YAML/AsciiDoc encoders shows up next to the Markdown path.
+func BenchmarkHelmDocsOutputFormats(b *testing.B) {
+	for _, format := range []string{"markdown", "json", "yaml", "asciidoc"} {
+		format := format
+		b.Run(format, func(b *testing.B) {
+			benchmarkHelmDocs(b, "benchmark", runtime.NumCPU(), format)
+		})
+	}
+}
+
+// benchmarkHelmDocs runs helmDocs() against the named testdata fixture with the given
+// --parallelism and --output-format. Flags are bound once via BindFlagValues before the b.N
+// loop starts; each helmDocs() call then reads them out of viper once up front, so the worker
+// pool never touches viper's global lock.
+func benchmarkHelmDocs(b *testing.B, fixture string, parallelism int, outputFormat string) {
 	// Copy testdata to a new temporary directory, to keep the working directory clean.
-	tmp := copyToTempDir(b, os.DirFS(filepath.Join("testdata", "benchmark")))
+	tmp := copyToTempDir(b, os.DirFS(filepath.Join("testdata", fixture)))
 
 	// Bind commandline flags.
 	// NOTE: Flags must be specified even if they use the default value.
+	//
+	// filesystem-type pins chart-search-root to the local-disk backend; other backends
+	// (oci://, git+https://, s3://, ...) are selected the same way in production use.
 	if err := viper.BindFlagValues(testFlagSet{
 		"chart-search-root":          tmp,
+		"filesystem-type":            string(document.FileFilesystemType),
+		"parallelism":                parallelism,
 		"log-level":                  "warn",
 		"ignore-file":                ".helmdocsignore",
-		"output-file":                "README.md",
+		"output-file":                "README." + outputFileExtensions[outputFormat],
+		"output-format":              outputFormat,
 		"sort-values-order":          document.AlphaNumSortOrder,
 		"document-dependency-values": true,
 	}); err != nil {
 		b.Fatal(err)
 	}
 
-	// Benchmark the main function.
+	b.ResetTimer()
+
+	// Benchmark the main function. Each chart in the fixture is fanned out across the
+	// --parallelism worker pool inside helmDocs(); b.ReportAllocs gives allocation counts per
+	// helmDocs() run over the whole fixture alongside the wall time captured by the testing
+	// package itself.
+	b.ReportAllocs()
 	for n := 0; n < b.N; n++ {
 		helmDocs(nil, nil)
 	}
+
+	// HELMDOCS_BENCH_REPORT=json|csv additionally emits a machine-readable per-chart timing
+	// report to stdout, for tooling that diffs benchmark runs across commits.
+	if format := os.Getenv("HELMDOCS_BENCH_REPORT"); format != "" {
+		reportBenchResults(b, tmp, format)
+	}
+}
+
+func reportBenchResults(b *testing.B, chartSearchRoot, format string) {
+	fsys, err := document.OpenFilesystem(document.FileFilesystemType, chartSearchRoot)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	writer, err := document.OpenWriter(document.FileFilesystemType, chartSearchRoot)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	charts, err := helm.FindCharts(fsys)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	results := processCharts(context.Background(), fsys, writer, charts, loadSettings())
+	if err := WriteBenchReport(os.Stdout, format, results); err != nil {
+		b.Fatal(err)
+	}
+}
+
+// outputFileExtensions maps --output-format to the file extension helmDocs() appends to
+// --output-file when that flag doesn't specify one explicitly.
+var outputFileExtensions = map[string]string{
+	"markdown": "md",
+	"json":     "json",
+	"yaml":     "yaml",
+	"asciidoc": "adoc",
 }
 
 var _ viper.FlagValueSet = &testFlagSet{}
@@ -86,22 +185,11 @@ func (v *testFlagValue) ValueType() string {
 	return v.typ
 }
 
-// copyToTempDir copies the specified readonly filesystem into a new temporary directory and returns
-// the path to the temporary directory. It fails the benchmark on any error and handles cleanup when
-// the benchmark finishes.
-func copyToTempDir(b *testing.B, fsys fs.FS) string {
-	// Create the temporary directory.
-	tmp, err := os.MkdirTemp("", "")
-	if err != nil {
-		b.Fatal(err)
-	}
-
-	// Register a cleanup function on the benchmark to clean up the temporary directory.
-	b.Cleanup(func() {
-		if err := os.RemoveAll(tmp); err != nil {
-			b.Fatal(err)
-		}
-	})
+// copyToTempDir copies the specified readonly filesystem into a new temporary directory managed by
+// tb and returns the path to the temporary directory. It fails tb on any error; tb.TempDir() handles
+// cleanup automatically, including on test/benchmark failure.
+func copyToTempDir(tb testing.TB, fsys fs.FS) string {
+	tmp := tb.TempDir()
 
 	// Copy the filesystem to the temporary directory.
 	if err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, _ error) error {
@@ -143,8 +231,82 @@ func copyToTempDir(b *testing.B, fsys fs.FS) string {
 
 		return nil
 	}); err != nil {
-		b.Fatal(err)
+		tb.Fatal(err)
 	}
 
 	return tmp
 }
+
+// TestWriteBenchReport covers both machine-readable formats regression benchmarks can emit.
+func TestWriteBenchReport(t *testing.T) {
+	results := []ChartResult{
+		{ChartDirectory: "charts/a", Duration: 1500 * time.Microsecond},
+		{ChartDirectory: "charts/b", Duration: 2500 * time.Microsecond, Err: fmt.Errorf("boom")},
+	}
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := WriteBenchReport(&buf, "json", results); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(buf.String(), `"ChartDirectory":"charts/a"`) {
+			t.Errorf("json report missing chart entry: %s", buf.String())
+		}
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := WriteBenchReport(&buf, "csv", results); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(buf.String(), "charts/b,2500000,boom") {
+			t.Errorf("csv report missing chart entry: %s", buf.String())
+		}
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		if err := WriteBenchReport(io.Discard, "xml", results); err == nil {
+			t.Error("expected an error for an unknown format")
+		}
+	})
+}
+
+// TestCopyToTempDir exercises copyToTempDir against in-memory fstest.MapFS fixtures, so permission
+// and content edge cases don't require maintaining files under testdata/.
+func TestCopyToTempDir(t *testing.T) {
+	testCases := map[string]struct {
+		fsys     fstest.MapFS
+		wantFile string
+		wantData string
+	}{
+		"single file": {
+			fsys: fstest.MapFS{
+				"Chart.yaml": &fstest.MapFile{Data: []byte("name: test\n")},
+			},
+			wantFile: "Chart.yaml",
+			wantData: "name: test\n",
+		},
+		"nested directory": {
+			fsys: fstest.MapFS{
+				"charts/dep/Chart.yaml": &fstest.MapFile{Data: []byte("name: dep\n")},
+			},
+			wantFile: filepath.Join("charts", "dep", "Chart.yaml"),
+			wantData: "name: dep\n",
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			tmp := copyToTempDir(t, tc.fsys)
+
+			got, err := os.ReadFile(filepath.Join(tmp, tc.wantFile))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tc.wantData {
+				t.Errorf("got %q, want %q", got, tc.wantData)
+			}
+		})
+	}
+}
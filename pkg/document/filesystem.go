@@ -0,0 +1,124 @@
+package document
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// FilesystemType selects which backend chart-search-root is resolved against.
+type FilesystemType string
+
+const (
+	// FileFilesystemType resolves chart-search-root against the local disk via os.DirFS. It is
+	// the default.
+	FileFilesystemType FilesystemType = "file"
+
+	// GitFilesystemType resolves chart-search-root by cloning a git repository via the git binary
+	// on PATH. root is "<repo-url>[#ref][//subdir]", e.g. "https://example.com/charts.git#main".
+	GitFilesystemType FilesystemType = "git"
+
+	// OCIFilesystemType resolves chart-search-root against an OCI registry (oci://registry/repo).
+	// Reserved for future work; selecting it returns an error today.
+	OCIFilesystemType FilesystemType = "oci"
+
+	// S3FilesystemType resolves chart-search-root against an S3 bucket (s3://bucket/prefix).
+	// Reserved for future work; selecting it returns an error today.
+	S3FilesystemType FilesystemType = "s3"
+)
+
+// filesystemOpener turns a chart-search-root value into an fs.FS rooted at that location.
+type filesystemOpener func(root string) (fs.FS, error)
+
+// filesystemBackends is the registry of chart discovery backends selectable via --filesystem-type.
+// Backends are registered here rather than switched on inline so new backends (oci, s3, ...)
+// can be added without touching call sites that only care about the resulting fs.FS.
+var filesystemBackends = map[FilesystemType]filesystemOpener{
+	FileFilesystemType: openFileFilesystem,
+	GitFilesystemType:  openGitFilesystem,
+	OCIFilesystemType:  unimplementedFilesystem(OCIFilesystemType),
+	S3FilesystemType:   unimplementedFilesystem(S3FilesystemType),
+}
+
+// OpenFilesystem returns the fs.FS that chart discovery and values-file reading should walk for
+// the given backend and root. root is interpreted by the backend: a local path for
+// FileFilesystemType, a "<repo-url>[#ref][//subdir]" reference for GitFilesystemType. The OCI
+// and S3 backends return an error for any root.
+func OpenFilesystem(fsType FilesystemType, root string) (fs.FS, error) {
+	open, ok := filesystemBackends[fsType]
+	if !ok {
+		return nil, fmt.Errorf("document: unknown filesystem type %q", fsType)
+	}
+
+	return open(root)
+}
+
+func openFileFilesystem(root string) (fs.FS, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf("document: chart-search-root %q: %w", root, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("document: chart-search-root %q is not a directory", root)
+	}
+
+	return os.DirFS(root), nil
+}
+
+// unimplementedFilesystem returns an opener that fails clearly, rather than silently falling back
+// to the local-disk backend, for filesystem types that have been reserved but not built yet.
+func unimplementedFilesystem(fsType FilesystemType) filesystemOpener {
+	return func(root string) (fs.FS, error) {
+		return nil, fmt.Errorf("document: filesystem backend %q is not implemented yet", fsType)
+	}
+}
+
+// Writer persists rendered documentation for a backend's charts, mirroring the read-side
+// OpenFilesystem registry above. The file backend writes into the local directory; the git
+// backend writes into its local checkout and does not commit or push; the OCI and S3 backends
+// have no writer and OpenWriter returns an error for them.
+type Writer interface {
+	// WriteFile writes data to the file at name, a slash-separated path relative to the backend's
+	// root, creating or truncating it as needed.
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+}
+
+type writerOpener func(root string) (Writer, error)
+
+// writerBackends mirrors filesystemBackends for the write side of a FilesystemType.
+var writerBackends = map[FilesystemType]writerOpener{
+	FileFilesystemType: openFileWriter,
+	GitFilesystemType:  openGitWriter,
+	OCIFilesystemType:  unimplementedWriter(OCIFilesystemType),
+	S3FilesystemType:   unimplementedWriter(S3FilesystemType),
+}
+
+// OpenWriter returns the Writer that rendered documentation should be written through for the
+// given backend and root.
+func OpenWriter(fsType FilesystemType, root string) (Writer, error) {
+	open, ok := writerBackends[fsType]
+	if !ok {
+		return nil, fmt.Errorf("document: unknown filesystem type %q", fsType)
+	}
+
+	return open(root)
+}
+
+type fileWriter struct {
+	root string
+}
+
+func (w fileWriter) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(filepath.Join(w.root, filepath.FromSlash(name)), data, perm)
+}
+
+func openFileWriter(root string) (Writer, error) {
+	return fileWriter{root: root}, nil
+}
+
+func unimplementedWriter(fsType FilesystemType) writerOpener {
+	return func(string) (Writer, error) {
+		return nil, fmt.Errorf("document: filesystem backend %q is not implemented yet", fsType)
+	}
+}
@@ -0,0 +1,121 @@
+package document
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// parseGitRoot splits a git chart-search-root of the form
+//
+//	<repo-url>[#ref][//subdir]
+//
+// into the repository to clone, the ref to check out (empty means the
+// remote's default branch), and a subdirectory within the checkout to use as
+// the chart search root (empty means the checkout root). A "git+" scheme
+// prefix, if present, is stripped before cloning since it's not a scheme git
+// itself understands.
+func parseGitRoot(root string) (repo, ref, subdir string) {
+	s := strings.TrimPrefix(root, "git+")
+
+	// Don't let the "//" in a "scheme://" prefix be mistaken for the "//subdir" separator.
+	scheme := ""
+	rest := s
+	if i := strings.Index(s, "://"); i >= 0 {
+		scheme, rest = s[:i+3], s[i+3:]
+	}
+
+	left := rest
+	if i := strings.Index(rest, "//"); i >= 0 {
+		left, subdir = rest[:i], rest[i+2:]
+	}
+
+	if i := strings.LastIndex(left, "#"); i >= 0 {
+		left, ref = left[:i], left[i+1:]
+	}
+
+	return scheme + left, ref, subdir
+}
+
+// openGitFilesystem clones root's repository into a temporary directory and returns an fs.FS
+// rooted at the requested subdirectory of the checkout, so chart discovery can walk a git remote
+// the same way it walks local disk.
+func openGitFilesystem(root string) (fs.FS, error) {
+	checkout, err := cloneGitRoot(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return openFileFilesystem(checkout)
+}
+
+// openGitWriter returns a Writer rooted at the same checkout a matching openGitFilesystem call
+// for the same root reads from, so rendered docs land in the working tree ready to be committed
+// and pushed by the caller. Pushing the result back upstream is left to the caller; helm-docs
+// itself only manages the local checkout.
+func openGitWriter(root string) (Writer, error) {
+	checkout, err := cloneGitRoot(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return openFileWriter(checkout)
+}
+
+// gitCheckouts caches the temporary clone directory for each chart-search-root already cloned in
+// this process, so a helmDocs() invocation's OpenFilesystem and OpenWriter calls for the same root
+// share one checkout instead of each cloning (and discarding) their own, which would silently
+// write rendered docs into a checkout nothing else ever looks at.
+var (
+	gitCheckoutsMu sync.Mutex
+	gitCheckouts   = map[string]string{}
+)
+
+func cloneGitRoot(root string) (string, error) {
+	gitCheckoutsMu.Lock()
+	defer gitCheckoutsMu.Unlock()
+
+	if dir, ok := gitCheckouts[root]; ok {
+		return dir, nil
+	}
+
+	if _, err := exec.LookPath("git"); err != nil {
+		return "", fmt.Errorf("document: git filesystem backend requires a git binary on PATH: %w", err)
+	}
+
+	repo, ref, subdir := parseGitRoot(root)
+	if repo == "" {
+		return "", fmt.Errorf("document: git chart-search-root %q has no repository", root)
+	}
+
+	dir, err := os.MkdirTemp("", "helm-docs-git-")
+	if err != nil {
+		return "", fmt.Errorf("document: creating git clone directory: %w", err)
+	}
+
+	clone := exec.Command("git", "clone", "--quiet", repo, dir)
+	if out, err := clone.CombinedOutput(); err != nil {
+		os.RemoveAll(dir)
+		return "", fmt.Errorf("document: cloning %q: %w: %s", repo, err, strings.TrimSpace(string(out)))
+	}
+
+	if ref != "" {
+		checkout := exec.Command("git", "-C", dir, "checkout", "--quiet", ref)
+		if out, err := checkout.CombinedOutput(); err != nil {
+			os.RemoveAll(dir)
+			return "", fmt.Errorf("document: checking out %q in %q: %w: %s", ref, repo, err, strings.TrimSpace(string(out)))
+		}
+	}
+
+	result := dir
+	if subdir != "" {
+		result = filepath.Join(dir, filepath.FromSlash(subdir))
+	}
+
+	gitCheckouts[root] = result
+	return result, nil
+}
@@ -0,0 +1,137 @@
+package document
+
+import (
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseGitRoot(t *testing.T) {
+	testCases := map[string]struct {
+		root       string
+		wantRepo   string
+		wantRef    string
+		wantSubdir string
+	}{
+		"bare url": {
+			root:     "https://example.com/charts.git",
+			wantRepo: "https://example.com/charts.git",
+		},
+		"git+ scheme is stripped": {
+			root:     "git+https://example.com/charts.git",
+			wantRepo: "https://example.com/charts.git",
+		},
+		"ref": {
+			root:     "https://example.com/charts.git#main",
+			wantRepo: "https://example.com/charts.git",
+			wantRef:  "main",
+		},
+		"subdir": {
+			root:       "https://example.com/charts.git//sub/dir",
+			wantRepo:   "https://example.com/charts.git",
+			wantSubdir: "sub/dir",
+		},
+		"ref and subdir": {
+			root:       "https://example.com/charts.git#v1.2.3//sub/dir",
+			wantRepo:   "https://example.com/charts.git",
+			wantRef:    "v1.2.3",
+			wantSubdir: "sub/dir",
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			repo, ref, subdir := parseGitRoot(tc.root)
+			if repo != tc.wantRepo || ref != tc.wantRef || subdir != tc.wantSubdir {
+				t.Errorf("parseGitRoot(%q) = (%q, %q, %q), want (%q, %q, %q)",
+					tc.root, repo, ref, subdir, tc.wantRepo, tc.wantRef, tc.wantSubdir)
+			}
+		})
+	}
+}
+
+// newLocalGitRepo creates a throwaway git repository on disk, committed on branch "main" plus a
+// "v1" tag, so openGitFilesystem can be exercised against a real `git clone` without any network
+// access.
+func newLocalGitRepo(t *testing.T) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available")
+	}
+
+	dir := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+		)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+
+	run("init", "--quiet", "--initial-branch=main")
+	if err := os.WriteFile(filepath.Join(dir, "Chart.yaml"), []byte("name: demo\nversion: 1.0.0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run("add", "Chart.yaml")
+	run("commit", "--quiet", "-m", "add chart")
+	run("tag", "v1")
+
+	return dir
+}
+
+func TestOpenGitFilesystem(t *testing.T) {
+	repo := newLocalGitRepo(t)
+
+	fsys, err := openGitFilesystem(repo)
+	if err != nil {
+		t.Fatalf("openGitFilesystem() error = %v", err)
+	}
+
+	data, err := fs.ReadFile(fsys, "Chart.yaml")
+	if err != nil {
+		t.Fatalf("reading Chart.yaml from cloned checkout: %v", err)
+	}
+	if string(data) != "name: demo\nversion: 1.0.0\n" {
+		t.Errorf("Chart.yaml = %q, want the committed content", data)
+	}
+}
+
+func TestOpenGitFilesystemRef(t *testing.T) {
+	repo := newLocalGitRepo(t)
+
+	run := exec.Command("git", "-C", repo, "commit", "--quiet", "--allow-empty", "-m", "second commit")
+	run.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+	)
+	if out, err := run.CombinedOutput(); err != nil {
+		t.Fatalf("git commit: %v: %s", err, out)
+	}
+
+	fsys, err := openGitFilesystem(repo + "#v1")
+	if err != nil {
+		t.Fatalf("openGitFilesystem() error = %v", err)
+	}
+
+	if _, err := fs.Stat(fsys, "Chart.yaml"); err != nil {
+		t.Errorf("Chart.yaml missing from checkout at ref v1: %v", err)
+	}
+}
+
+func TestOpenGitFilesystemUnknownRepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available")
+	}
+
+	if _, err := openGitFilesystem(filepath.Join(t.TempDir(), "does-not-exist")); err == nil {
+		t.Error("expected an error cloning a nonexistent repository")
+	}
+}
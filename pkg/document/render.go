@@ -0,0 +1,131 @@
+package document
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/norwoodj/helm-docs/pkg/helm"
+)
+
+// OutputFormat selects the serialization --output-format renders a chart's documentation as.
+type OutputFormat string
+
+const (
+	MarkdownOutputFormat OutputFormat = "markdown"
+	JSONOutputFormat     OutputFormat = "json"
+	YAMLOutputFormat     OutputFormat = "yaml"
+	AsciiDocOutputFormat OutputFormat = "asciidoc"
+)
+
+// defaultOutputFileExtensions maps each OutputFormat to the file extension --output-file is
+// inferred to use when the flag doesn't specify one explicitly.
+var defaultOutputFileExtensions = map[OutputFormat]string{
+	MarkdownOutputFormat: "md",
+	"":                   "md",
+	JSONOutputFormat:     "json",
+	YAMLOutputFormat:     "yaml",
+	AsciiDocOutputFormat: "adoc",
+}
+
+// DefaultFileExtension returns the file extension conventionally used for format, or an error if
+// format isn't one helm-docs knows how to render.
+func DefaultFileExtension(format OutputFormat) (string, error) {
+	ext, ok := defaultOutputFileExtensions[format]
+	if !ok {
+		return "", fmt.Errorf("document: unknown output-format %q", format)
+	}
+	return ext, nil
+}
+
+// ChartDocument is the stable schema rendered for a single chart: its Chart.yaml metadata plus
+// its flattened values table. Downstream tooling (docs sites, catalog UIs, policy engines) that
+// consumes --output-format=json/yaml output should be able to rely on these field names.
+type ChartDocument struct {
+	Name         string            `json:"name" yaml:"name"`
+	Description  string            `json:"description,omitempty" yaml:"description,omitempty"`
+	Version      string            `json:"version,omitempty" yaml:"version,omitempty"`
+	AppVersion   string            `json:"appVersion,omitempty" yaml:"appVersion,omitempty"`
+	Maintainers  []helm.Maintainer `json:"maintainers,omitempty" yaml:"maintainers,omitempty"`
+	Dependencies []helm.Dependency `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
+	Values       []ValueRow        `json:"values,omitempty" yaml:"values,omitempty"`
+}
+
+// NewChartDocument assembles the stable ChartDocument schema from a chart's parsed metadata and
+// flattened values.
+func NewChartDocument(info helm.ChartInfo, values []ValueRow) ChartDocument {
+	return ChartDocument{
+		Name:         info.Name,
+		Description:  info.Description,
+		Version:      info.Version,
+		AppVersion:   info.AppVersion,
+		Maintainers:  info.Maintainers,
+		Dependencies: info.Dependencies,
+		Values:       values,
+	}
+}
+
+// Render serializes doc in the given format.
+func Render(doc ChartDocument, format OutputFormat) ([]byte, error) {
+	switch format {
+	case MarkdownOutputFormat, "":
+		return renderMarkdown(doc), nil
+	case JSONOutputFormat:
+		out, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("document: rendering %s as json: %w", doc.Name, err)
+		}
+		return append(out, '\n'), nil
+	case YAMLOutputFormat:
+		out, err := yaml.Marshal(doc)
+		if err != nil {
+			return nil, fmt.Errorf("document: rendering %s as yaml: %w", doc.Name, err)
+		}
+		return out, nil
+	case AsciiDocOutputFormat:
+		return renderAsciiDoc(doc), nil
+	default:
+		return nil, fmt.Errorf("document: unknown output-format %q", format)
+	}
+}
+
+func renderMarkdown(doc ChartDocument) []byte {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", doc.Name)
+	if doc.Description != "" {
+		fmt.Fprintf(&b, "%s\n\n", doc.Description)
+	}
+
+	if len(doc.Values) > 0 {
+		b.WriteString("| Key | Type | Default |\n")
+		b.WriteString("|-----|------|---------|\n")
+		for _, v := range doc.Values {
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", v.Key, v.Type, v.Default)
+		}
+		b.WriteString("\n")
+	}
+
+	return []byte(b.String())
+}
+
+func renderAsciiDoc(doc ChartDocument) []byte {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "= %s\n\n", doc.Name)
+	if doc.Description != "" {
+		fmt.Fprintf(&b, "%s\n\n", doc.Description)
+	}
+
+	if len(doc.Values) > 0 {
+		b.WriteString("[cols=\"1,1,1\", options=\"header\"]\n|===\n|Key |Type |Default\n\n")
+		for _, v := range doc.Values {
+			fmt.Fprintf(&b, "|%s\n|%s\n|%s\n\n", v.Key, v.Type, v.Default)
+		}
+		b.WriteString("|===\n")
+	}
+
+	return []byte(b.String())
+}
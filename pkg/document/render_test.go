@@ -0,0 +1,76 @@
+package document
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/norwoodj/helm-docs/pkg/helm"
+)
+
+func testDoc() ChartDocument {
+	return NewChartDocument(helm.ChartInfo{
+		Name:        "demo",
+		Description: "a demo chart",
+		Version:     "1.0.0",
+	}, []ValueRow{
+		{Key: "replicas", Type: "int", Default: "1"},
+	})
+}
+
+func TestRender(t *testing.T) {
+	doc := testDoc()
+
+	testCases := map[string]struct {
+		format  OutputFormat
+		wantSub string
+	}{
+		"markdown":            {MarkdownOutputFormat, "# demo"},
+		"default is markdown": {"", "# demo"},
+		"json":                {JSONOutputFormat, `"name": "demo"`},
+		"yaml":                {YAMLOutputFormat, "name: demo"},
+		"asciidoc":            {AsciiDocOutputFormat, "= demo"},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			out, err := Render(doc, tc.format)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if !strings.Contains(string(out), tc.wantSub) {
+				t.Errorf("Render(%q) = %q, want substring %q", tc.format, out, tc.wantSub)
+			}
+		})
+	}
+}
+
+func TestRenderUnknownFormat(t *testing.T) {
+	if _, err := Render(testDoc(), "bogus"); err == nil {
+		t.Error("expected an error for an unknown output-format")
+	}
+}
+
+func TestDefaultFileExtension(t *testing.T) {
+	testCases := map[OutputFormat]string{
+		MarkdownOutputFormat: "md",
+		"":                   "md",
+		JSONOutputFormat:     "json",
+		YAMLOutputFormat:     "yaml",
+		AsciiDocOutputFormat: "adoc",
+	}
+
+	for format, want := range testCases {
+		got, err := DefaultFileExtension(format)
+		if err != nil {
+			t.Fatalf("DefaultFileExtension(%q) error = %v", format, err)
+		}
+		if got != want {
+			t.Errorf("DefaultFileExtension(%q) = %q, want %q", format, got, want)
+		}
+	}
+
+	if _, err := DefaultFileExtension("bogus"); err == nil {
+		t.Error("expected an error for an unknown output-format")
+	}
+}
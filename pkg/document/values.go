@@ -0,0 +1,180 @@
+package document
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/norwoodj/helm-docs/pkg/helm"
+)
+
+// SortOrder controls the order ValueRows are emitted in for a chart's values table.
+type SortOrder string
+
+const (
+	// AlphaNumSortOrder sorts values alphanumerically by key.
+	AlphaNumSortOrder SortOrder = "alphanum"
+
+	// FileSortOrder preserves the order values appear in values.yaml.
+	FileSortOrder SortOrder = "file"
+)
+
+// ValueRow is a single row of a chart's rendered values table.
+type ValueRow struct {
+	Key     string `json:"key" yaml:"key"`
+	Type    string `json:"type" yaml:"type"`
+	Default string `json:"default" yaml:"default"`
+}
+
+// LoadValues reads values.yaml for the chart rooted at chartDir on fsys and flattens it into a
+// list of ValueRows ordered by order. A chart with no values.yaml yields an empty, non-error
+// result since values.yaml is optional.
+func LoadValues(fsys fs.FS, chartDir string, order SortOrder) ([]ValueRow, error) {
+	rows, err := loadRawValues(fsys, chartDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := SortValues(rows, order); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// LoadDependencyValues is LoadValues plus, when the chart declares dependencies, each
+// dependency's own values.yaml (read from the conventional "charts/<name>" subdirectory) appended
+// with its keys prefixed by the dependency's name - e.g. a "password" key in the "postgresql"
+// dependency's values.yaml becomes "postgresql.password" in the result. A dependency with no
+// vendored chart under charts/ (and so no values.yaml to read) contributes no rows.
+func LoadDependencyValues(fsys fs.FS, chart helm.ChartInfo, order SortOrder) ([]ValueRow, error) {
+	rows, err := loadRawValues(fsys, chart.ChartDirectory)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dep := range chart.Dependencies {
+		depRows, err := loadRawValues(fsys, path.Join(chart.ChartDirectory, "charts", dep.Name))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range depRows {
+			row.Key = dep.Name + "." + row.Key
+			rows = append(rows, row)
+		}
+	}
+
+	if err := SortValues(rows, order); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// SortValues orders rows in place according to order.
+func SortValues(rows []ValueRow, order SortOrder) error {
+	switch order {
+	case FileSortOrder:
+		// Already in file order; nothing to do.
+	case AlphaNumSortOrder, "":
+		sort.Slice(rows, func(i, j int) bool { return rows[i].Key < rows[j].Key })
+	default:
+		return fmt.Errorf("document: unknown sort-values-order %q", order)
+	}
+
+	return nil
+}
+
+// loadRawValues reads and flattens values.yaml for the chart rooted at chartDir, in file order. A
+// chart with no values.yaml yields an empty, non-error result since values.yaml is optional.
+func loadRawValues(fsys fs.FS, chartDir string) ([]ValueRow, error) {
+	valuesPath := path.Join(chartDir, "values.yaml")
+
+	raw, err := fs.ReadFile(fsys, valuesPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("document: reading %s: %w", valuesPath, err)
+	}
+
+	var values yaml.Node
+	if err := yaml.Unmarshal(raw, &values); err != nil {
+		return nil, fmt.Errorf("document: parsing %s: %w", valuesPath, err)
+	}
+
+	if len(values.Content) == 0 {
+		return nil, nil
+	}
+
+	return flattenValues("", values.Content[0]), nil
+}
+
+func flattenValues(prefix string, node *yaml.Node) []ValueRow {
+	if node == nil {
+		return nil
+	}
+
+	if node.Kind != yaml.MappingNode || (len(node.Content) == 0 && prefix != "") {
+		return []ValueRow{{
+			Key:     prefix,
+			Type:    valueType(node),
+			Default: nodeDefault(node),
+		}}
+	}
+
+	var rows []ValueRow
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		rows = append(rows, flattenValues(key, node.Content[i+1])...)
+	}
+
+	return rows
+}
+
+func valueType(node *yaml.Node) string {
+	switch node.Tag {
+	case "!!bool":
+		return "bool"
+	case "!!int":
+		return "int"
+	case "!!float":
+		return "float"
+	case "!!null":
+		return "null"
+	case "!!seq":
+		return "list"
+	case "!!map":
+		return "object"
+	default:
+		return "string"
+	}
+}
+
+// nodeDefault renders node's default value as it should appear in a values table. Scalars use
+// their literal text; sequences and maps (e.g. a `tolerations: []` list) are re-marshaled in flow
+// style so they still render as a single cell instead of being silently dropped.
+func nodeDefault(node *yaml.Node) string {
+	if node.Kind == yaml.ScalarNode {
+		return node.Value
+	}
+
+	flow := *node
+	flow.Style = yaml.FlowStyle
+
+	out, err := yaml.Marshal(&flow)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(out))
+}
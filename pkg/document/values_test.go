@@ -0,0 +1,164 @@
+package document
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/norwoodj/helm-docs/pkg/helm"
+)
+
+func TestLoadValues(t *testing.T) {
+	testCases := map[string]struct {
+		fsys fstest.MapFS
+		want []ValueRow
+	}{
+		"no values.yaml": {
+			fsys: fstest.MapFS{
+				"Chart.yaml": &fstest.MapFile{Data: []byte("name: demo\n")},
+			},
+			want: nil,
+		},
+		"scalar types": {
+			fsys: fstest.MapFS{
+				"values.yaml": &fstest.MapFile{Data: []byte(
+					"replicas: 3\n" +
+						"enabled: true\n" +
+						"ratio: 0.5\n" +
+						"nameOverride:\n" +
+						"image: nginx\n",
+				)},
+			},
+			want: []ValueRow{
+				{Key: "enabled", Type: "bool", Default: "true"},
+				{Key: "image", Type: "string", Default: "nginx"},
+				{Key: "nameOverride", Type: "null", Default: ""},
+				{Key: "ratio", Type: "float", Default: "0.5"},
+				{Key: "replicas", Type: "int", Default: "3"},
+			},
+		},
+		"nested keys are dotted": {
+			fsys: fstest.MapFS{
+				"values.yaml": &fstest.MapFile{Data: []byte(
+					"image:\n  repository: nginx\n  tag: latest\n",
+				)},
+			},
+			want: []ValueRow{
+				{Key: "image.repository", Type: "string", Default: "nginx"},
+				{Key: "image.tag", Type: "string", Default: "latest"},
+			},
+		},
+		"empty list keeps its default": {
+			fsys: fstest.MapFS{
+				"values.yaml": &fstest.MapFile{Data: []byte("tolerations: []\n")},
+			},
+			want: []ValueRow{
+				{Key: "tolerations", Type: "list", Default: "[]"},
+			},
+		},
+		"populated list is flattened to one row": {
+			fsys: fstest.MapFS{
+				"values.yaml": &fstest.MapFile{Data: []byte("ports:\n  - 80\n  - 443\n")},
+			},
+			want: []ValueRow{
+				{Key: "ports", Type: "list", Default: "[80, 443]"},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got, err := LoadValues(tc.fsys, ".", AlphaNumSortOrder)
+			if err != nil {
+				t.Fatalf("LoadValues() error = %v", err)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("LoadValues() = %+v, want %+v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("row %d = %+v, want %+v", i, got[i], tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLoadValuesSortOrder(t *testing.T) {
+	fsys := fstest.MapFS{
+		"values.yaml": &fstest.MapFile{Data: []byte("zeta: 1\nalpha: 2\n")},
+	}
+
+	alpha, err := LoadValues(fsys, ".", AlphaNumSortOrder)
+	if err != nil {
+		t.Fatalf("LoadValues(AlphaNumSortOrder) error = %v", err)
+	}
+	if alpha[0].Key != "alpha" || alpha[1].Key != "zeta" {
+		t.Errorf("AlphaNumSortOrder got %+v, want alpha before zeta", alpha)
+	}
+
+	file, err := LoadValues(fsys, ".", FileSortOrder)
+	if err != nil {
+		t.Fatalf("LoadValues(FileSortOrder) error = %v", err)
+	}
+	if file[0].Key != "zeta" || file[1].Key != "alpha" {
+		t.Errorf("FileSortOrder got %+v, want file order preserved", file)
+	}
+}
+
+func TestLoadValuesUnknownSortOrder(t *testing.T) {
+	fsys := fstest.MapFS{
+		"values.yaml": &fstest.MapFile{Data: []byte("replicas: 1\n")},
+	}
+
+	if _, err := LoadValues(fsys, ".", "bogus"); err == nil {
+		t.Error("expected an error for an unknown sort-values-order")
+	}
+}
+
+func TestLoadDependencyValues(t *testing.T) {
+	fsys := fstest.MapFS{
+		"values.yaml":                   &fstest.MapFile{Data: []byte("replicas: 1\n")},
+		"charts/postgresql/values.yaml": &fstest.MapFile{Data: []byte("password: secret\n")},
+	}
+	chart := helm.ChartInfo{
+		ChartDirectory: ".",
+		Dependencies:   []helm.Dependency{{Name: "postgresql"}},
+	}
+
+	got, err := LoadDependencyValues(fsys, chart, AlphaNumSortOrder)
+	if err != nil {
+		t.Fatalf("LoadDependencyValues() error = %v", err)
+	}
+
+	want := []ValueRow{
+		{Key: "postgresql.password", Type: "string", Default: "secret"},
+		{Key: "replicas", Type: "int", Default: "1"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("LoadDependencyValues() = %+v, want %+v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("row %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestLoadDependencyValuesNoVendoredChart(t *testing.T) {
+	fsys := fstest.MapFS{
+		"values.yaml": &fstest.MapFile{Data: []byte("replicas: 1\n")},
+	}
+	chart := helm.ChartInfo{
+		ChartDirectory: ".",
+		Dependencies:   []helm.Dependency{{Name: "postgresql"}},
+	}
+
+	got, err := LoadDependencyValues(fsys, chart, AlphaNumSortOrder)
+	if err != nil {
+		t.Fatalf("LoadDependencyValues() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Key != "replicas" {
+		t.Errorf("LoadDependencyValues() = %+v, want just replicas", got)
+	}
+}
@@ -0,0 +1,87 @@
+// Package helm discovers Helm charts on an fs.FS and parses the metadata helm-docs renders.
+package helm
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Maintainer is a single entry from a chart's maintainers list.
+type Maintainer struct {
+	Name  string `yaml:"name"`
+	Email string `yaml:"email,omitempty"`
+	URL   string `yaml:"url,omitempty"`
+}
+
+// Dependency is a single entry from a chart's dependencies list.
+type Dependency struct {
+	Name       string `yaml:"name"`
+	Version    string `yaml:"version,omitempty"`
+	Repository string `yaml:"repository,omitempty"`
+}
+
+// ChartInfo is the metadata helm-docs reads out of Chart.yaml for a single chart.
+type ChartInfo struct {
+	// ChartDirectory is the chart's directory relative to the root of the fs.FS it was
+	// discovered on.
+	ChartDirectory string `yaml:"-"`
+
+	Name         string       `yaml:"name"`
+	Version      string       `yaml:"version,omitempty"`
+	AppVersion   string       `yaml:"appVersion,omitempty"`
+	Description  string       `yaml:"description,omitempty"`
+	Maintainers  []Maintainer `yaml:"maintainers,omitempty"`
+	Dependencies []Dependency `yaml:"dependencies,omitempty"`
+}
+
+// FindCharts walks fsys and returns the ChartInfo for every directory containing a Chart.yaml,
+// sorted by ChartDirectory so callers get a stable processing order regardless of how the
+// underlying fs.FS enumerates entries.
+func FindCharts(fsys fs.FS) ([]ChartInfo, error) {
+	var chartDirs []string
+
+	if err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || d.Name() != "Chart.yaml" {
+			return nil
+		}
+		chartDirs = append(chartDirs, path.Dir(p))
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("helm: walking chart search root: %w", err)
+	}
+
+	sort.Strings(chartDirs)
+
+	charts := make([]ChartInfo, 0, len(chartDirs))
+	for _, dir := range chartDirs {
+		info, err := parseChartYaml(fsys, dir)
+		if err != nil {
+			return nil, err
+		}
+		charts = append(charts, info)
+	}
+
+	return charts, nil
+}
+
+func parseChartYaml(fsys fs.FS, chartDir string) (ChartInfo, error) {
+	raw, err := fs.ReadFile(fsys, path.Join(chartDir, "Chart.yaml"))
+	if err != nil {
+		return ChartInfo{}, fmt.Errorf("helm: reading %s: %w", path.Join(chartDir, "Chart.yaml"), err)
+	}
+
+	var info ChartInfo
+	if err := yaml.Unmarshal(raw, &info); err != nil {
+		return ChartInfo{}, fmt.Errorf("helm: parsing %s: %w", path.Join(chartDir, "Chart.yaml"), err)
+	}
+	info.ChartDirectory = chartDir
+
+	return info, nil
+}
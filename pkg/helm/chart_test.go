@@ -0,0 +1,77 @@
+package helm
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestFindCharts(t *testing.T) {
+	fsys := fstest.MapFS{
+		"charts/b/Chart.yaml": &fstest.MapFile{Data: []byte(
+			"name: b\nversion: 2.0.0\ndescription: chart b\n",
+		)},
+		"charts/a/Chart.yaml": &fstest.MapFile{Data: []byte(
+			"name: a\n" +
+				"version: 1.0.0\n" +
+				"appVersion: \"1.2\"\n" +
+				"description: chart a\n" +
+				"maintainers:\n" +
+				"  - name: jane\n" +
+				"    email: jane@example.com\n" +
+				"dependencies:\n" +
+				"  - name: dep\n" +
+				"    version: 0.1.0\n" +
+				"    repository: https://example.com/charts\n",
+		)},
+		"charts/a/values.yaml": &fstest.MapFile{Data: []byte("replicas: 1\n")},
+	}
+
+	charts, err := FindCharts(fsys)
+	if err != nil {
+		t.Fatalf("FindCharts() error = %v", err)
+	}
+	if len(charts) != 2 {
+		t.Fatalf("FindCharts() returned %d charts, want 2", len(charts))
+	}
+
+	// Sorted by ChartDirectory, so charts/a comes before charts/b regardless of map iteration order.
+	a, b := charts[0], charts[1]
+
+	if a.ChartDirectory != "charts/a" || a.Name != "a" || a.Version != "1.0.0" || a.AppVersion != "1.2" {
+		t.Errorf("charts[0] = %+v, want charts/a metadata", a)
+	}
+	if len(a.Maintainers) != 1 || a.Maintainers[0].Name != "jane" {
+		t.Errorf("charts[0].Maintainers = %+v, want [{jane ...}]", a.Maintainers)
+	}
+	if len(a.Dependencies) != 1 || a.Dependencies[0].Name != "dep" {
+		t.Errorf("charts[0].Dependencies = %+v, want [{dep ...}]", a.Dependencies)
+	}
+
+	if b.ChartDirectory != "charts/b" || b.Name != "b" {
+		t.Errorf("charts[1] = %+v, want charts/b metadata", b)
+	}
+}
+
+func TestFindChartsNoCharts(t *testing.T) {
+	fsys := fstest.MapFS{
+		"README.md": &fstest.MapFile{Data: []byte("not a chart\n")},
+	}
+
+	charts, err := FindCharts(fsys)
+	if err != nil {
+		t.Fatalf("FindCharts() error = %v", err)
+	}
+	if len(charts) != 0 {
+		t.Errorf("FindCharts() = %+v, want none", charts)
+	}
+}
+
+func TestFindChartsInvalidYAML(t *testing.T) {
+	fsys := fstest.MapFS{
+		"broken/Chart.yaml": &fstest.MapFile{Data: []byte("name: [unterminated\n")},
+	}
+
+	if _, err := FindCharts(fsys); err == nil {
+		t.Error("expected an error for invalid Chart.yaml")
+	}
+}
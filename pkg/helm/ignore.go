@@ -0,0 +1,76 @@
+package helm
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// LoadIgnorePatterns reads the ignore file named name from fsys and returns its patterns: one
+// non-blank, non-comment ("#...") line per pattern. A missing ignore file is not an error, since
+// it's optional - it yields no patterns.
+func LoadIgnorePatterns(fsys fs.FS, name string) ([]string, error) {
+	raw, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("helm: reading %s: %w", name, err)
+	}
+
+	var patterns []string
+	for _, line := range strings.Split(string(raw), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		// A trailing slash is the gitignore idiom for "match this name as a directory only";
+		// since every match target here is already a directory, the slash itself never
+		// participates in the glob.
+		line = strings.TrimSuffix(line, "/")
+		if _, err := path.Match(line, ""); err != nil {
+			return nil, fmt.Errorf("helm: %s: invalid pattern %q: %w", name, line, err)
+		}
+		patterns = append(patterns, line)
+	}
+
+	return patterns, nil
+}
+
+// Ignored reports whether chartDir, a slash-separated chart directory relative to the search
+// root, matches any of patterns. A pattern matches if it matches chartDir's full path or any one
+// of its path segments via path.Match, so a pattern like "examples" excludes any chart under a
+// directory named "examples" regardless of depth, the same way a bare name in a .gitignore does.
+// Patterns are assumed already validated by LoadIgnorePatterns, so match errors are ignored here.
+func Ignored(chartDir string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, chartDir); ok {
+			return true
+		}
+		for _, segment := range strings.Split(chartDir, "/") {
+			if ok, _ := path.Match(pattern, segment); ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// FilterIgnored returns the subset of charts whose ChartDirectory doesn't match any of patterns.
+func FilterIgnored(charts []ChartInfo, patterns []string) []ChartInfo {
+	if len(patterns) == 0 {
+		return charts
+	}
+
+	kept := make([]ChartInfo, 0, len(charts))
+	for _, chart := range charts {
+		if !Ignored(chart.ChartDirectory, patterns) {
+			kept = append(kept, chart)
+		}
+	}
+
+	return kept
+}
@@ -0,0 +1,103 @@
+package helm
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadIgnorePatterns(t *testing.T) {
+	testCases := map[string]struct {
+		fsys fstest.MapFS
+		want []string
+	}{
+		"missing file": {
+			fsys: fstest.MapFS{},
+			want: nil,
+		},
+		"patterns with comments and blank lines": {
+			fsys: fstest.MapFS{
+				".helmdocsignore": &fstest.MapFile{Data: []byte(
+					"# comment\n\nexamples\ncharts/internal-*\n",
+				)},
+			},
+			want: []string{"examples", "charts/internal-*"},
+		},
+		"trailing slash is stripped": {
+			fsys: fstest.MapFS{
+				".helmdocsignore": &fstest.MapFile{Data: []byte("examples/\n")},
+			},
+			want: []string{"examples"},
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got, err := LoadIgnorePatterns(tc.fsys, ".helmdocsignore")
+			if err != nil {
+				t.Fatalf("LoadIgnorePatterns() error = %v", err)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("LoadIgnorePatterns() = %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("LoadIgnorePatterns()[%d] = %q, want %q", i, got[i], tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLoadIgnorePatternsInvalidPattern(t *testing.T) {
+	fsys := fstest.MapFS{
+		".helmdocsignore": &fstest.MapFile{Data: []byte("charts/[internal\n")},
+	}
+
+	if _, err := LoadIgnorePatterns(fsys, ".helmdocsignore"); err == nil {
+		t.Error("LoadIgnorePatterns() error = nil, want an error for an unbalanced character class")
+	}
+}
+
+func TestIgnored(t *testing.T) {
+	testCases := map[string]struct {
+		chartDir string
+		patterns []string
+		want     bool
+	}{
+		"no patterns":     {chartDir: "charts/a", patterns: nil, want: false},
+		"full path match": {chartDir: "charts/a", patterns: []string{"charts/a"}, want: true},
+		"segment match":   {chartDir: "charts/examples/demo", patterns: []string{"examples"}, want: true},
+		"glob match":      {chartDir: "charts/internal-foo", patterns: []string{"internal-*"}, want: true},
+		"no match":        {chartDir: "charts/a", patterns: []string{"charts/b", "examples"}, want: false},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			if got := Ignored(tc.chartDir, tc.patterns); got != tc.want {
+				t.Errorf("Ignored(%q, %v) = %v, want %v", tc.chartDir, tc.patterns, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFilterIgnored(t *testing.T) {
+	charts := []ChartInfo{
+		{ChartDirectory: "charts/a"},
+		{ChartDirectory: "charts/examples/demo"},
+		{ChartDirectory: "charts/b"},
+	}
+
+	got := FilterIgnored(charts, []string{"examples"})
+	if len(got) != 2 {
+		t.Fatalf("FilterIgnored() = %+v, want 2 charts", got)
+	}
+	if got[0].ChartDirectory != "charts/a" || got[1].ChartDirectory != "charts/b" {
+		t.Errorf("FilterIgnored() = %+v, want charts/a and charts/b", got)
+	}
+
+	if got := FilterIgnored(charts, nil); len(got) != len(charts) {
+		t.Errorf("FilterIgnored() with no patterns = %+v, want all charts unchanged", got)
+	}
+}